@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"google.golang.org/api/iterator"
+)
+
+// addressCleaner cleans up unused regional and global static IP addresses.
+type addressCleaner struct{}
+
+func (addressCleaner) Name() string           { return "static-ips" }
+func (addressCleaner) Dependencies() []string { return []string{"target-pools", "forwarding-rules"} }
+
+func (addressCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	var resources []Resource
+
+	client, err := compute.NewAddressesRESTClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create addresses client: %w", err)
+	}
+	defer client.Close()
+
+	it := client.AggregatedList(ctx, &computepb.AggregatedListAddressesRequest{Project: projectID})
+	for {
+		pair, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing addresses: %w", err)
+		}
+
+		for _, address := range pair.Value.Addresses {
+			if address.GetStatus() == "IN_USE" {
+				continue
+			}
+			resources = append(resources, Resource{
+				Name:       address.GetName(),
+				Location:   extractRegionFromURL(address.GetRegion()),
+				Status:     address.GetStatus(),
+				CreateTime: address.GetCreationTimestamp(),
+				Labels:     address.GetLabels(),
+			})
+		}
+	}
+
+	globalClient, err := compute.NewGlobalAddressesRESTClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create global addresses client: %w", err)
+	}
+	defer globalClient.Close()
+
+	globalIt := globalClient.List(ctx, &computepb.ListGlobalAddressesRequest{Project: projectID})
+	for {
+		globalAddr, err := globalIt.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing global addresses: %w", err)
+		}
+
+		if globalAddr.GetStatus() == "IN_USE" {
+			continue
+		}
+		resources = append(resources, Resource{
+			Name:       globalAddr.GetName(),
+			Location:   "global",
+			Status:     globalAddr.GetStatus(),
+			CreateTime: globalAddr.GetCreationTimestamp(),
+			Labels:     globalAddr.GetLabels(),
+		})
+	}
+
+	return resources, nil
+}
+
+func (addressCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	if resource.Location == "global" {
+		client, err := compute.NewGlobalAddressesRESTClient(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create global addresses client: %w", err)
+		}
+		defer client.Close()
+
+		op, err := client.Delete(ctx, &computepb.DeleteGlobalAddressRequest{
+			Project: projectID,
+			Address: resource.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to release global address %s: %w", resource.Name, err)
+		}
+		return op.Wait(ctx)
+	}
+
+	client, err := compute.NewAddressesRESTClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create addresses client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Delete(ctx, &computepb.DeleteAddressRequest{
+		Project: projectID,
+		Region:  resource.Location,
+		Address: resource.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release address %s: %w", resource.Name, err)
+	}
+	return op.Wait(ctx)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// bucketCleaner cleans up Cloud Storage buckets, including their object
+// versions. It batch-deletes a bucket's objects via its own Batch drawn
+// from the shared pool, so those deletes share the pool's worker slots and
+// FamilyStorage rate limiter with every other cleaner instead of each
+// bucket getting its own independent limiter.
+type bucketCleaner struct {
+	pool           *Pool
+	forceRetention bool
+	forceLocked    bool
+}
+
+// NewBucketCleaner returns a cleaner for Cloud Storage buckets. pool is the
+// shared worker pool object deletes are submitted to. forceRetention
+// removes a bucket's (unlocked) retention policy before deleting it;
+// forceLocked allows deleting buckets whose retention policy is locked.
+func NewBucketCleaner(pool *Pool, forceRetention, forceLocked bool) *bucketCleaner {
+	return &bucketCleaner{
+		pool:           pool,
+		forceRetention: forceRetention,
+		forceLocked:    forceLocked,
+	}
+}
+
+func (bucketCleaner) Name() string           { return "buckets" }
+func (bucketCleaner) Dependencies() []string { return nil }
+
+func (c bucketCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage client: %w", err)
+	}
+	defer client.Close()
+
+	var resources []Resource
+	it := client.Buckets(ctx, projectID)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing buckets: %w", err)
+		}
+
+		if attrs.RetentionPolicy != nil && attrs.RetentionPolicy.IsLocked && !c.forceLocked {
+			log.Printf("  Skipping bucket %s (locked retention policy; pass --force-locked to delete anyway)", attrs.Name)
+			continue
+		}
+
+		resources = append(resources, Resource{
+			Name:       attrs.Name,
+			Location:   attrs.Location,
+			Status:     attrs.StorageClass,
+			CreateTime: attrs.Created.Format(time.RFC3339),
+			Labels:     attrs.Labels,
+		})
+	}
+
+	return resources, nil
+}
+
+func (c bucketCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create storage client: %w", err)
+	}
+	defer client.Close()
+
+	bucket := client.Bucket(resource.Name)
+
+	attrs, err := bucket.Attrs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read attrs for bucket %s: %w", resource.Name, err)
+	}
+
+	if attrs.RetentionPolicy != nil {
+		if attrs.RetentionPolicy.IsLocked && !c.forceLocked {
+			return fmt.Errorf("bucket %s has a locked retention policy; rerun with --force-locked", resource.Name)
+		}
+		if !attrs.RetentionPolicy.IsLocked {
+			if !c.forceRetention {
+				return fmt.Errorf("bucket %s has a retention policy; rerun with --force-retention", resource.Name)
+			}
+			if _, err := bucket.Update(ctx, storage.BucketAttrsToUpdate{RetentionPolicy: &storage.RetentionPolicy{}}); err != nil {
+				return fmt.Errorf("failed to remove retention policy on bucket %s: %w", resource.Name, err)
+			}
+		}
+	}
+
+	if err := c.deleteAllObjects(ctx, bucket, resource.Name); err != nil {
+		return fmt.Errorf("failed to delete objects in bucket %s: %w", resource.Name, err)
+	}
+
+	if err := bucket.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete bucket %s: %w", resource.Name, err)
+	}
+
+	return nil
+}
+
+// deleteAllObjects deletes every object in bucket, including non-current
+// versions, via a Batch drawn from the shared pool.
+func (c *bucketCleaner) deleteAllObjects(ctx context.Context, bucket *storage.BucketHandle, bucketName string) error {
+	batch := c.pool.NewBatch()
+
+	objCount := 0
+	it := bucket.Objects(ctx, &storage.Query{Versions: true})
+	for {
+		objAttrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error listing objects: %w", err)
+		}
+
+		objCount++
+		name, generation := objAttrs.Name, objAttrs.Generation
+		batch.Go(ctx, FamilyStorage, func() error {
+			if err := bucket.Object(name).Generation(generation).Delete(ctx); err != nil {
+				return fmt.Errorf("failed to delete object %s#%d: %w", name, generation, err)
+			}
+			return nil
+		})
+	}
+
+	if err := batch.Wait(); err != nil {
+		return err
+	}
+
+	if objCount > 0 {
+		log.Printf("  Deleted %d object versions from bucket %s", objCount, bucketName)
+	}
+	return nil
+}
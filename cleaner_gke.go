@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+)
+
+// gkeClusterCleaner cleans up GKE clusters, both zonal and regional.
+// Resource.Name holds just the cluster's short name and Resource.Location
+// its zone or region; Delete reassembles the full
+// "projects/{p}/locations/{loc}/clusters/{name}" path from those.
+type gkeClusterCleaner struct{}
+
+func (gkeClusterCleaner) Name() string           { return "gke-clusters" }
+func (gkeClusterCleaner) Dependencies() []string { return nil }
+
+func (gkeClusterCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	client, err := container.NewClusterManagerClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+	defer client.Close()
+
+	// "-" matches all zones and regions.
+	parent := fmt.Sprintf("projects/%s/locations/-", projectID)
+	resp, err := client.ListClusters(ctx, &containerpb.ListClustersRequest{Parent: parent})
+	if err != nil {
+		return nil, fmt.Errorf("error listing GKE clusters: %w", err)
+	}
+
+	var resources []Resource
+	for _, cluster := range resp.GetClusters() {
+		resources = append(resources, Resource{
+			Name:       cluster.GetName(),
+			Location:   cluster.GetLocation(),
+			Status:     cluster.GetStatus().String(),
+			CreateTime: cluster.GetCreateTime(),
+			Labels:     cluster.GetResourceLabels(),
+		})
+	}
+
+	return resources, nil
+}
+
+func (gkeClusterCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	client, err := container.NewClusterManagerClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster manager client: %w", err)
+	}
+	defer client.Close()
+
+	name := fmt.Sprintf("projects/%s/locations/%s/clusters/%s", projectID, resource.Location, resource.Name)
+	if _, err := client.DeleteCluster(ctx, &containerpb.DeleteClusterRequest{Name: name}); err != nil {
+		return fmt.Errorf("failed to delete GKE cluster %s: %w", resource.Name, err)
+	}
+	return nil
+}
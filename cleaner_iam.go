@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/iam/v1"
+)
+
+// serviceAccountCleaner cleans up service accounts. Which ones are actually
+// selected for deletion is entirely up to the common --include/--exclude
+// filter; this cleaner lists every service account in the project.
+type serviceAccountCleaner struct{}
+
+func (serviceAccountCleaner) Name() string           { return "service-accounts" }
+func (serviceAccountCleaner) Dependencies() []string { return nil }
+
+func (serviceAccountCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IAM service: %w", err)
+	}
+
+	resp, err := iamService.Projects.ServiceAccounts.List("projects/" + projectID).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	var resources []Resource
+	for _, sa := range resp.Accounts {
+		// The IAM v1 ServiceAccount type exposes no creation time or labels,
+		// so CreateTime/Labels-based filters can never select these.
+		resources = append(resources, Resource{
+			Name:     sa.Name,
+			Location: "global",
+			Status:   sa.Email,
+		})
+	}
+
+	return resources, nil
+}
+
+func (serviceAccountCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	iamService, err := iam.NewService(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create IAM service: %w", err)
+	}
+
+	if _, err := iamService.Projects.ServiceAccounts.Delete(resource.Name).Do(); err != nil {
+		return fmt.Errorf("failed to delete service account %s: %w", resource.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"google.golang.org/api/iterator"
+)
+
+// targetPoolCleaner cleans up regional target pools.
+type targetPoolCleaner struct{}
+
+func (targetPoolCleaner) Name() string { return "target-pools" }
+
+// Dependencies returns forwarding-rules: a forwarding rule can reference a
+// target pool, so the rule must be deleted first or GCP rejects the target
+// pool's deletion.
+func (targetPoolCleaner) Dependencies() []string { return []string{"forwarding-rules"} }
+
+func (targetPoolCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	client, err := compute.NewTargetPoolsRESTClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create target pools client: %w", err)
+	}
+	defer client.Close()
+
+	var resources []Resource
+	it := client.AggregatedList(ctx, &computepb.AggregatedListTargetPoolsRequest{Project: projectID})
+	for {
+		pair, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing target pools: %w", err)
+		}
+
+		for _, pool := range pair.Value.TargetPools {
+			resources = append(resources, Resource{
+				Name:       pool.GetName(),
+				Location:   extractRegionFromURL(pool.GetRegion()),
+				Users:      pool.GetInstances(),
+				CreateTime: pool.GetCreationTimestamp(),
+				// Target pools don't support labels.
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+func (targetPoolCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	client, err := compute.NewTargetPoolsRESTClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create target pools client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Delete(ctx, &computepb.DeleteTargetPoolRequest{
+		Project:    projectID,
+		Region:     resource.Location,
+		TargetPool: resource.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete target pool %s: %w", resource.Name, err)
+	}
+	return op.Wait(ctx)
+}
+
+// forwardingRuleCleaner cleans up regional forwarding rules.
+type forwardingRuleCleaner struct{}
+
+func (forwardingRuleCleaner) Name() string           { return "forwarding-rules" }
+func (forwardingRuleCleaner) Dependencies() []string { return nil }
+
+func (forwardingRuleCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	client, err := compute.NewForwardingRulesRESTClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forwarding rules client: %w", err)
+	}
+	defer client.Close()
+
+	var resources []Resource
+	it := client.AggregatedList(ctx, &computepb.AggregatedListForwardingRulesRequest{Project: projectID})
+	for {
+		pair, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing forwarding rules: %w", err)
+		}
+
+		for _, rule := range pair.Value.ForwardingRules {
+			resources = append(resources, Resource{
+				Name:       rule.GetName(),
+				Location:   extractRegionFromURL(rule.GetRegion()),
+				CreateTime: rule.GetCreationTimestamp(),
+				Labels:     rule.GetLabels(),
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+func (forwardingRuleCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	client, err := compute.NewForwardingRulesRESTClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create forwarding rules client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Delete(ctx, &computepb.DeleteForwardingRuleRequest{
+		Project:        projectID,
+		Region:         resource.Location,
+		ForwardingRule: resource.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete forwarding rule %s: %w", resource.Name, err)
+	}
+	return op.Wait(ctx)
+}
+
+// firewallRuleCleaner cleans up VPC firewall rules.
+type firewallRuleCleaner struct{}
+
+func (firewallRuleCleaner) Name() string           { return "firewall-rules" }
+func (firewallRuleCleaner) Dependencies() []string { return nil }
+
+func (firewallRuleCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	client, err := compute.NewFirewallsRESTClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firewalls client: %w", err)
+	}
+	defer client.Close()
+
+	var resources []Resource
+	it := client.List(ctx, &computepb.ListFirewallsRequest{Project: projectID})
+	for {
+		fw, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing firewall rules: %w", err)
+		}
+
+		resources = append(resources, Resource{
+			Name:       fw.GetName(),
+			Location:   "global",
+			CreateTime: fw.GetCreationTimestamp(),
+			// Firewall rules don't support labels.
+		})
+	}
+
+	return resources, nil
+}
+
+func (firewallRuleCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	client, err := compute.NewFirewallsRESTClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create firewalls client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Delete(ctx, &computepb.DeleteFirewallRequest{
+		Project:  projectID,
+		Firewall: resource.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete firewall rule %s: %w", resource.Name, err)
+	}
+	return op.Wait(ctx)
+}
+
+// networkCleaner cleans up VPC networks. It must run after every other
+// cleaner that might still hold a reference to a network.
+type networkCleaner struct{}
+
+func (networkCleaner) Name() string {
+	return "vpc-networks"
+}
+
+func (networkCleaner) Dependencies() []string {
+	return []string{"firewall-rules", "forwarding-rules", "target-pools", "static-ips", "vm-instances", "gke-clusters"}
+}
+
+func (networkCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	client, err := compute.NewNetworksRESTClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create networks client: %w", err)
+	}
+	defer client.Close()
+
+	var resources []Resource
+	it := client.List(ctx, &computepb.ListNetworksRequest{Project: projectID})
+	for {
+		network, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing networks: %w", err)
+		}
+
+		resources = append(resources, Resource{
+			Name:       network.GetName(),
+			Location:   "global",
+			CreateTime: network.GetCreationTimestamp(),
+			// VPC networks don't support labels.
+		})
+	}
+
+	return resources, nil
+}
+
+func (networkCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	client, err := compute.NewNetworksRESTClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create networks client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Delete(ctx, &computepb.DeleteNetworkRequest{
+		Project: projectID,
+		Network: resource.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete network %s: %w", resource.Name, err)
+	}
+	return op.Wait(ctx)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"google.golang.org/api/iterator"
+)
+
+// vmInstanceCleaner cleans up Compute Engine VM instances across all zones.
+type vmInstanceCleaner struct{}
+
+func (vmInstanceCleaner) Name() string           { return "vm-instances" }
+func (vmInstanceCleaner) Dependencies() []string { return nil }
+
+func (vmInstanceCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	client, err := compute.NewInstancesRESTClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create instances client: %w", err)
+	}
+	defer client.Close()
+
+	req := &computepb.AggregatedListInstancesRequest{Project: projectID}
+
+	var resources []Resource
+	it := client.AggregatedList(ctx, req)
+	for {
+		pair, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing instances: %w", err)
+		}
+
+		for _, instance := range pair.Value.Instances {
+			resources = append(resources, Resource{
+				Name:       instance.GetName(),
+				Location:   extractZoneFromURL(instance.GetZone()),
+				Status:     instance.GetStatus(),
+				CreateTime: instance.GetCreationTimestamp(),
+				Labels:     instance.GetLabels(),
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+func (vmInstanceCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	client, err := compute.NewInstancesRESTClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create instances client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Delete(ctx, &computepb.DeleteInstanceRequest{
+		Project:  projectID,
+		Zone:     resource.Location,
+		Instance: resource.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete instance %s: %w", resource.Name, err)
+	}
+	return op.Wait(ctx)
+}
+
+// diskCleaner cleans up unattached persistent disks across all zones.
+type diskCleaner struct{}
+
+func (diskCleaner) Name() string           { return "disks" }
+func (diskCleaner) Dependencies() []string { return []string{"vm-instances"} }
+
+func (diskCleaner) List(ctx context.Context, projectID string) ([]Resource, error) {
+	client, err := compute.NewDisksRESTClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create disks client: %w", err)
+	}
+	defer client.Close()
+
+	req := &computepb.AggregatedListDisksRequest{Project: projectID}
+
+	var resources []Resource
+	it := client.AggregatedList(ctx, req)
+	for {
+		pair, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing disks: %w", err)
+		}
+
+		for _, disk := range pair.Value.Disks {
+			if len(disk.GetUsers()) > 0 {
+				// Still attached to an instance; skip it.
+				continue
+			}
+			resources = append(resources, Resource{
+				Name:       disk.GetName(),
+				Location:   extractZoneFromURL(disk.GetZone()),
+				Status:     disk.GetStatus(),
+				CreateTime: disk.GetCreationTimestamp(),
+				Labels:     disk.GetLabels(),
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+func (diskCleaner) Delete(ctx context.Context, projectID string, resource Resource) error {
+	client, err := compute.NewDisksRESTClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create disks client: %w", err)
+	}
+	defer client.Close()
+
+	op, err := client.Delete(ctx, &computepb.DeleteDiskRequest{
+		Project: projectID,
+		Zone:    resource.Location,
+		Disk:    resource.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete disk %s: %w", resource.Name, err)
+	}
+	return op.Wait(ctx)
+}
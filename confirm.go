@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// confirmMu serializes confirmProjectInteractive prompts so that running
+// several projects concurrently (--parallel-projects) doesn't interleave
+// their prompts and responses on the shared terminal.
+var confirmMu sync.Mutex
+
+// projectSummary is the per-resource-type count shown to the user before a
+// destructive run, so "flip dryRun to false" isn't the only thing standing
+// between a typo and a wiped project.
+type projectSummary struct {
+	resourceType string
+	count        int
+}
+
+func printProjectSummary(projectID string, summary []projectSummary) {
+	fmt.Printf("\nAbout to delete the following resources in project %q:\n", projectID)
+	for _, s := range summary {
+		fmt.Printf("  %-20s %d\n", s.resourceType, s.count)
+	}
+}
+
+// confirmProject prints summary and asks the user to type projectID back
+// to proceed. It reads from in and writes the prompt to out. A mismatched
+// or empty response means "do not proceed".
+func confirmProject(in io.Reader, out io.Writer, projectID string, summary []projectSummary) bool {
+	fmt.Fprintf(out, "\nAbout to delete the following resources in project %q:\n", projectID)
+	for _, s := range summary {
+		fmt.Fprintf(out, "  %-20s %d\n", s.resourceType, s.count)
+	}
+	fmt.Fprintf(out, "Type the project ID (%s) to confirm, or anything else to skip it: ", projectID)
+
+	reader := bufio.NewReader(in)
+	line, _ := reader.ReadString('\n')
+	line = trimNewline(line)
+
+	return line == projectID
+}
+
+func trimNewline(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// confirmProjectInteractive is the production entry point: it reads from
+// stdin and writes to stdout.
+func confirmProjectInteractive(projectID string, summary []projectSummary) bool {
+	confirmMu.Lock()
+	defer confirmMu.Unlock()
+	return confirmProject(os.Stdin, os.Stdout, projectID, summary)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v3"
+)
+
+// DiscoverProjects enumerates project IDs via Cloud Resource Manager. At
+// least one of folderID or label must be non-empty. folderID restricts the
+// search to descendants of "folders/{id}"; label is a "key=value" pair
+// every returned project must carry. When both are given, a project must
+// satisfy both: each is searched separately and the results are
+// intersected client-side, since joining them into one query string would
+// have the Search API OR them together instead.
+func DiscoverProjects(ctx context.Context, folderID, label string) ([]string, error) {
+	svc, err := cloudresourcemanager.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Resource Manager service: %w", err)
+	}
+
+	var clauses []string
+	if folderID != "" {
+		clauses = append(clauses, fmt.Sprintf("parent=folders/%s", folderID))
+	}
+	if label != "" {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --label %q, expected key=value", label)
+		}
+		clauses = append(clauses, fmt.Sprintf("labels.%s=%s", key, value))
+	}
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("at least one of --folder or --label is required for project discovery")
+	}
+
+	results := make([][]string, len(clauses))
+	for i, clause := range clauses {
+		projectIDs, err := searchProjects(ctx, svc, clause)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = projectIDs
+	}
+
+	return intersectProjectIDs(results), nil
+}
+
+// searchProjects runs a single Cloud Resource Manager Projects.Search query
+// and returns every matching project ID.
+func searchProjects(ctx context.Context, svc *cloudresourcemanager.Service, query string) ([]string, error) {
+	var projectIDs []string
+	call := svc.Projects.Search().Query(query)
+	err := call.Pages(ctx, func(resp *cloudresourcemanager.SearchProjectsResponse) error {
+		for _, project := range resp.Projects {
+			projectIDs = append(projectIDs, project.ProjectId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error searching projects for %q: %w", query, err)
+	}
+	return projectIDs, nil
+}
+
+// intersectProjectIDs returns the project IDs common to every result set in
+// sets. A single set is returned unchanged.
+func intersectProjectIDs(sets [][]string) []string {
+	if len(sets) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int, len(sets[0]))
+	for _, set := range sets {
+		seen := make(map[string]bool, len(set))
+		for _, id := range set {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			counts[id]++
+		}
+	}
+
+	var result []string
+	for id, count := range counts {
+		if count == len(sets) {
+			result = append(result, id)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ReadProjectFile reads one project ID per line from path, ignoring blank
+// lines and lines starting with "#".
+func ReadProjectFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open project file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var projectIDs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		projectIDs = append(projectIDs, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading project file %s: %w", path, err)
+	}
+
+	return projectIDs, nil
+}
+
+// resolveProjectIDs decides which projects to operate on, in priority
+// order: an explicit --project-file, then --folder/--label discovery,
+// falling back to the projectIDs configured in source.
+func resolveProjectIDs(ctx context.Context, projectFile, folderID, label string) ([]string, error) {
+	if projectFile != "" {
+		return ReadProjectFile(projectFile)
+	}
+	if folderID != "" || label != "" {
+		return DiscoverProjects(ctx, folderID, label)
+	}
+	return projectIDs, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestIntersectProjectIDs exercises the client-side AND that lets
+// --folder and --label both narrow the result set, per the documented
+// Cloud Resource Manager Projects.Search semantics: each clause is OR'd
+// with the rest of its own query string, but separate clauses searched
+// independently must be intersected to act like AND.
+func TestIntersectProjectIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		sets [][]string
+		want []string
+	}{
+		{
+			name: "single set returned unchanged",
+			sets: [][]string{{"b-proj", "a-proj"}},
+			want: []string{"a-proj", "b-proj"},
+		},
+		{
+			name: "two sets intersect to common projects",
+			sets: [][]string{
+				{"a-proj", "b-proj", "c-proj"},
+				{"b-proj", "c-proj", "d-proj"},
+			},
+			want: []string{"b-proj", "c-proj"},
+		},
+		{
+			name: "no overlap yields empty result",
+			sets: [][]string{
+				{"a-proj"},
+				{"b-proj"},
+			},
+			want: nil,
+		},
+		{
+			name: "duplicate ids within a set count once",
+			sets: [][]string{
+				{"a-proj", "a-proj"},
+				{"a-proj"},
+			},
+			want: []string{"a-proj"},
+		},
+		{
+			name: "no sets yields nil",
+			sets: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := intersectProjectIDs(tt.sets)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("intersectProjectIDs(%v) = %v, want %v", tt.sets, got, tt.want)
+			}
+		})
+	}
+}
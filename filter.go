@@ -0,0 +1,369 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Predicate decides whether a Resource should be selected for an operation
+// (currently: deletion). Every cleaner runs its List() results through a
+// Predicate before acting on them.
+type Predicate interface {
+	Match(r Resource) bool
+}
+
+// PredicateFunc adapts a plain function to the Predicate interface.
+type PredicateFunc func(r Resource) bool
+
+func (f PredicateFunc) Match(r Resource) bool { return f(r) }
+
+// matchNone is the zero-value-safe default: it matches nothing, so a
+// misconfigured or absent filter can never cause a full-project wipe.
+type matchNone struct{}
+
+func (matchNone) Match(Resource) bool { return false }
+
+// MatchNone returns the "match nothing" predicate used as the default
+// filter when no include/exclude rule has been configured.
+func MatchNone() Predicate { return matchNone{} }
+
+// And returns a predicate that matches only when every one of preds
+// matches. And() with no arguments matches everything, matching the usual
+// empty-AND convention.
+func And(preds ...Predicate) Predicate {
+	return PredicateFunc(func(r Resource) bool {
+		for _, p := range preds {
+			if !p.Match(r) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Or returns a predicate that matches when any one of preds matches.
+// Or() with no arguments matches nothing.
+func Or(preds ...Predicate) Predicate {
+	return PredicateFunc(func(r Resource) bool {
+		for _, p := range preds {
+			if p.Match(r) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Not returns a predicate that matches whenever p does not.
+func Not(p Predicate) Predicate {
+	return PredicateFunc(func(r Resource) bool { return !p.Match(r) })
+}
+
+// nameRegexPredicate matches resources whose Name matches a regular
+// expression, e.g. "name~^vsa-sa-gcnv".
+type nameRegexPredicate struct {
+	re *regexp.Regexp
+}
+
+func (p nameRegexPredicate) Match(r Resource) bool { return p.re.MatchString(r.Name) }
+
+// agePredicate matches resources older (">") or younger ("<") than a
+// duration, e.g. "age>72h".
+type agePredicate struct {
+	op  string // ">" or "<"
+	min time.Duration
+	now func() time.Time
+}
+
+func (p agePredicate) Match(r Resource) bool {
+	if r.CreateTime == "" {
+		return false
+	}
+	created, err := time.Parse(time.RFC3339, r.CreateTime)
+	if err != nil {
+		return false
+	}
+	age := p.now().Sub(created)
+	if p.op == ">" {
+		return age > p.min
+	}
+	return age < p.min
+}
+
+// labelPredicate matches resources carrying a label, e.g. "label:env=dev".
+// An empty want value matches any value for the given key.
+type labelPredicate struct {
+	key  string
+	want string
+}
+
+func (p labelPredicate) Match(r Resource) bool {
+	got, ok := r.Labels[p.key]
+	if !ok {
+		return false
+	}
+	return p.want == "" || got == p.want
+}
+
+// globPredicate matches a field (currently only "zone"/"location") against
+// a shell-style glob, e.g. "zone=us-central1-*".
+type globPredicate struct {
+	field string
+	re    *regexp.Regexp
+}
+
+func (p globPredicate) Match(r Resource) bool {
+	switch p.field {
+	case "zone", "location", "region":
+		return p.re.MatchString(r.Location)
+	case "status":
+		return p.re.MatchString(r.Status)
+	default:
+		return false
+	}
+}
+
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// ParsePredicate parses a single filter clause into a Predicate. Supported
+// forms:
+//
+//	name~<regexp>          resource name matches a regular expression
+//	age><duration>         resource is older than duration (e.g. age>72h)
+//	age<<duration>         resource is younger than duration
+//	label:<key>[=<value>]  resource carries a label, optionally with value
+//	<field>=<glob>         field (zone, location, region, status) matches a
+//	                       shell-style glob, e.g. zone=us-central1-*
+func ParsePredicate(expr string) (Predicate, error) {
+	expr = strings.TrimSpace(expr)
+	switch {
+	case strings.HasPrefix(expr, "name~"):
+		pattern := strings.TrimPrefix(expr, "name~")
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name filter %q: %w", expr, err)
+		}
+		return nameRegexPredicate{re: re}, nil
+
+	case strings.HasPrefix(expr, "age>") || strings.HasPrefix(expr, "age<"):
+		op := string(expr[3])
+		durStr := expr[4:]
+		dur, err := time.ParseDuration(durStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age filter %q: %w", expr, err)
+		}
+		return agePredicate{op: op, min: dur, now: time.Now}, nil
+
+	case strings.HasPrefix(expr, "label:"):
+		rest := strings.TrimPrefix(expr, "label:")
+		key, value, _ := strings.Cut(rest, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid label filter %q: missing key", expr)
+		}
+		return labelPredicate{key: key, want: value}, nil
+
+	default:
+		field, glob, ok := strings.Cut(expr, "=")
+		if !ok {
+			return nil, fmt.Errorf("unrecognized filter clause %q", expr)
+		}
+		re, err := globToRegexp(glob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob in filter %q: %w", expr, err)
+		}
+		return globPredicate{field: field, re: re}, nil
+	}
+}
+
+// ParseBoolExpr parses a filter expression composed of clauses joined with
+// "&&", "||", "!" and parentheses, e.g.:
+//
+//	name~^vsa-sa-gcnv && age>72h && !label:keep=true
+//
+// Operator precedence is the usual NOT > AND > OR.
+func ParseBoolExpr(expr string) (Predicate, error) {
+	p := &boolExprParser{tokens: tokenizeBoolExpr(expr)}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected trailing tokens starting at %q", p.tokens[p.pos])
+	}
+	return pred, nil
+}
+
+type boolExprParser struct {
+	tokens []string
+	pos    int
+}
+
+// tokenizeBoolExpr splits expr into "&&", "||", "!", "(", ")" operator
+// tokens and clause tokens. A clause's own content is opaque to the
+// tokenizer: once a clause has started, parentheses it balances itself
+// (e.g. the regex alternation in "name~^(foo|bar)$") and literal whitespace
+// (e.g. a glob like "zone=us central1-*") are kept as part of it rather
+// than split out. A clause only ends at the next top-level "&&"/"||" or a
+// ")" that closes a paren the clause didn't itself open.
+func tokenizeBoolExpr(expr string) []string {
+	var tokens []string
+	var cur strings.Builder
+	inClause := false
+	clauseParenDepth := 0
+
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+		inClause = false
+		clauseParenDepth = 0
+	}
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if inClause {
+			switch {
+			case r == '(':
+				clauseParenDepth++
+				cur.WriteRune(r)
+			case r == ')' && clauseParenDepth > 0:
+				clauseParenDepth--
+				cur.WriteRune(r)
+			case r == ')':
+				flush()
+				tokens = append(tokens, ")")
+			case r == '&' && i+1 < len(runes) && runes[i+1] == '&':
+				flush()
+				tokens = append(tokens, "&&")
+				i++
+			case r == '|' && i+1 < len(runes) && runes[i+1] == '|':
+				flush()
+				tokens = append(tokens, "||")
+				i++
+			default:
+				cur.WriteRune(r)
+			}
+			continue
+		}
+
+		switch {
+		case r == '&' && i+1 < len(runes) && runes[i+1] == '&':
+			tokens = append(tokens, "&&")
+			i++
+		case r == '|' && i+1 < len(runes) && runes[i+1] == '|':
+			tokens = append(tokens, "||")
+			i++
+		case r == '!':
+			tokens = append(tokens, "!")
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t':
+			// no-op between clauses
+		default:
+			inClause = true
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+func (p *boolExprParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *boolExprParser) parseOr() (Predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	preds := []Predicate{left}
+	for p.peek() == "||" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, right)
+	}
+	if len(preds) == 1 {
+		return preds[0], nil
+	}
+	return Or(preds...), nil
+}
+
+func (p *boolExprParser) parseAnd() (Predicate, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	preds := []Predicate{left}
+	for p.peek() == "&&" {
+		p.pos++
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, right)
+	}
+	if len(preds) == 1 {
+		return preds[0], nil
+	}
+	return And(preds...), nil
+}
+
+func (p *boolExprParser) parseNot() (Predicate, error) {
+	if p.peek() == "!" {
+		p.pos++
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return Not(inner), nil
+	}
+	return p.parseAtom()
+}
+
+func (p *boolExprParser) parseAtom() (Predicate, error) {
+	tok := p.peek()
+	if tok == "" {
+		return nil, fmt.Errorf("unexpected end of filter expression")
+	}
+	if tok == "(" {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return inner, nil
+	}
+	p.pos++
+	return ParsePredicate(tok)
+}
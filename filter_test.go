@@ -0,0 +1,136 @@
+package main
+
+import "testing"
+
+func TestParseBoolExprPrecedence(t *testing.T) {
+	dev := Resource{Name: "vsa-sa-gcnv-dev", Labels: map[string]string{"keep": "true"}}
+	prod := Resource{Name: "vsa-sa-gcnv-prod", Labels: map[string]string{"keep": "false"}}
+	other := Resource{Name: "other-resource"}
+
+	tests := []struct {
+		name string
+		expr string
+		r    Resource
+		want bool
+	}{
+		{
+			name: "AND binds tighter than OR",
+			// matches either "name~dev && label:keep=true" or "name~other"
+			expr: "name~dev && label:keep=true || name~other",
+			r:    dev,
+			want: true,
+		},
+		{
+			name: "OR does not short-circuit the AND on the other side",
+			expr: "name~dev && label:keep=true || name~other",
+			r:    prod,
+			want: false,
+		},
+		{
+			name: "OR alternative still matches",
+			expr: "name~dev && label:keep=true || name~other",
+			r:    other,
+			want: true,
+		},
+		{
+			name: "NOT binds tighter than AND",
+			expr: "!label:keep=true && name~vsa-sa-gcnv",
+			r:    prod,
+			want: true,
+		},
+		{
+			name: "NOT applied to true label excludes it",
+			expr: "!label:keep=true && name~vsa-sa-gcnv",
+			r:    dev,
+			want: false,
+		},
+		{
+			name: "parentheses override default precedence",
+			expr: "name~dev && (label:keep=true || label:keep=false)",
+			r:    dev,
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pred, err := ParseBoolExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseBoolExpr(%q) returned error: %v", tt.expr, err)
+			}
+			if got := pred.Match(tt.r); got != tt.want {
+				t.Errorf("ParseBoolExpr(%q).Match(%+v) = %v, want %v", tt.expr, tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBoolExprMalformed(t *testing.T) {
+	tests := []string{
+		"",
+		"name~dev &&",
+		"&& name~dev",
+		"(name~dev",
+		"name~dev)",
+		"name~dev || || name~prod",
+		"!",
+		"()",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseBoolExpr(expr); err == nil {
+				t.Errorf("ParseBoolExpr(%q) returned nil error, want an error", expr)
+			}
+		})
+	}
+}
+
+// TestParseBoolExprClauseContentIsOpaque covers clauses whose own content
+// includes parentheses or whitespace, which the tokenizer must not mistake
+// for its own grouping syntax or an operator boundary.
+func TestParseBoolExprClauseContentIsOpaque(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		r    Resource
+		want bool
+	}{
+		{
+			name: "regex alternation in parentheses is part of the clause",
+			expr: "name~^(foo|bar)-instance$",
+			r:    Resource{Name: "foo-instance"},
+			want: true,
+		},
+		{
+			name: "regex alternation still respects top-level grouping",
+			expr: "(name~^(foo|bar)-instance$) && label:keep=true",
+			r:    Resource{Name: "bar-instance", Labels: map[string]string{"keep": "true"}},
+			want: true,
+		},
+		{
+			name: "literal space in a glob value is preserved",
+			expr: "status=in use",
+			r:    Resource{Status: "in use"},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pred, err := ParseBoolExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseBoolExpr(%q) returned error: %v", tt.expr, err)
+			}
+			if got := pred.Match(tt.r); got != tt.want {
+				t.Errorf("ParseBoolExpr(%q).Match(%+v) = %v, want %v", tt.expr, tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePredicateUnrecognized(t *testing.T) {
+	if _, err := ParsePredicate("not a clause at all"); err == nil {
+		t.Error("ParsePredicate with no recognized operator returned nil error, want an error")
+	}
+}
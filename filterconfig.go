@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FilterConfig is the on-disk shape of a --filter-config YAML file. Each
+// list entry is a clause or boolean expression understood by
+// ParseBoolExpr.
+type FilterConfig struct {
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// BuildPredicate combines the include/exclude expressions into a single
+// Predicate: a resource is selected if it matches any include expression
+// and no exclude expression.
+func (c FilterConfig) BuildPredicate() (Predicate, error) {
+	include, err := parseExprList(c.Include)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include filter: %w", err)
+	}
+	exclude, err := parseExprList(c.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude filter: %w", err)
+	}
+
+	if len(include) == 0 {
+		return MatchNone(), nil
+	}
+
+	pred := Or(include...)
+	if len(exclude) > 0 {
+		pred = And(pred, Not(Or(exclude...)))
+	}
+	return pred, nil
+}
+
+func parseExprList(exprs []string) ([]Predicate, error) {
+	preds := make([]Predicate, 0, len(exprs))
+	for _, expr := range exprs {
+		pred, err := ParseBoolExpr(expr)
+		if err != nil {
+			return nil, err
+		}
+		preds = append(preds, pred)
+	}
+	return preds, nil
+}
+
+// LoadFilterConfig reads and parses a --filter-config YAML file into a
+// Predicate.
+func LoadFilterConfig(path string) (Predicate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read filter config %s: %w", path, err)
+	}
+
+	var cfg FilterConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse filter config %s: %w", path, err)
+	}
+
+	return cfg.BuildPredicate()
+}
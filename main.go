@@ -2,16 +2,13 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 	"sync"
-
-	compute "cloud.google.com/go/compute/apiv1"
-	"cloud.google.com/go/compute/apiv1/computepb"
-	"cloud.google.com/go/storage"
-	"google.golang.org/api/iam/v1"
-	"google.golang.org/api/iterator"
+	"time"
 )
 
 // Configuration
@@ -22,398 +19,289 @@ var (
 		"mfc4dc04826a8d270-tp",
 		"u6c7e2e4892fda638-tp",
 	}
-
-	// Set to true to actually delete resources, false for dry-run
-	dryRun = false
 )
 
-func main() {
-	ctx := context.Background()
+// stringListFlag collects repeated occurrences of a flag into a slice, e.g.
+// --include 'name~^vsa-sa-gcnv' --include 'age>72h'.
+type stringListFlag []string
 
-	log.Printf("Starting GCP cleanup script (Dry Run: %v)", dryRun)
-	log.Printf("Projects to clean: %v", projectIDs)
+func (f *stringListFlag) String() string { return strings.Join(*f, ",") }
 
-	for _, projectID := range projectIDs {
-		log.Printf("\n========== Processing Project: %s ==========", projectID)
-		cleanupProject(ctx, projectID)
-	}
-
-	log.Println("\n========== Cleanup Complete ==========")
+func (f *stringListFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
 }
 
-func cleanupProject(ctx context.Context, projectID string) {
-	// Delete VMs first and wait for completion
-	if err := deleteVMInstances(ctx, projectID); err != nil {
-		log.Printf("Error deleting VM instances in %s: %v", projectID, err)
+func main() {
+	var includeExprs, excludeExprs stringListFlag
+	filterConfigPath := flag.String("filter-config", "", "path to a YAML file with include/exclude filter expressions")
+	flag.Var(&includeExprs, "include", "filter expression a resource must match to be selected (repeatable, OR'd together)")
+	flag.Var(&excludeExprs, "exclude", "filter expression that excludes a matching resource (repeatable, OR'd together)")
+	reportJSONPath := flag.String("report-json", "cleanup-report.json", "path to write the JSON run report")
+	reportCSVPath := flag.String("report-csv", "cleanup-report.csv", "path to write the CSV run report")
+	maxParallel := flag.Int("max-parallel", 10, "maximum number of concurrent delete operations")
+	yes := flag.Bool("yes", false, "skip the interactive confirmation prompt and delete immediately")
+	interactive := flag.Bool("interactive", true, "require typing the project ID to confirm before deleting anything in it")
+	forceRetention := flag.Bool("force-retention", false, "remove a bucket's unlocked retention policy before deleting it")
+	forceLocked := flag.Bool("force-locked", false, "delete buckets even if they have a locked retention policy")
+	folderID := flag.String("folder", "", "discover projects under this Cloud Resource Manager folder ID")
+	label := flag.String("label", "", "discover projects carrying this label, as key=value")
+	projectFile := flag.String("project-file", "", "path to a file listing one project ID per line")
+	parallelProjects := flag.Int("parallel-projects", 1, "number of projects to clean up concurrently")
+	dryRun := flag.Bool("dry-run", false, "list what would be deleted without deleting anything")
+	flag.Parse()
+
+	resourceFilter, err := buildFilter(*filterConfigPath, includeExprs, excludeExprs)
+	if err != nil {
+		log.Fatalf("Invalid filter configuration: %v", err)
 	}
 
-	// After VMs are deleted, run other cleanup tasks concurrently (skip buckets)
-	var wg sync.WaitGroup
-	wg.Add(3)
-
-	go func() {
-		defer wg.Done()
-		if err := deleteDisks(ctx, projectID); err != nil {
-			log.Printf("Error deleting disks in %s: %v", projectID, err)
-		}
-	}()
+	assumeYes := *yes || !*interactive
 
-	go func() {
-		defer wg.Done()
-		if err := releaseStaticIPs(ctx, projectID); err != nil {
-			log.Printf("Error releasing static IPs in %s: %v", projectID, err)
-		}
-	}()
+	ctx := context.Background()
 
-	go func() {
-		defer wg.Done()
-		if err := deleteServiceAccounts(ctx, projectID); err != nil {
-			log.Printf("Error deleting service accounts in %s: %v", projectID, err)
-		}
-	}()
+	targetProjectIDs, err := resolveProjectIDs(ctx, *projectFile, *folderID, *label)
+	if err != nil {
+		log.Fatalf("Failed to resolve project list: %v", err)
+	}
 
-	wg.Wait()
+	reporter := NewReporter()
+	pool := NewPool(*maxParallel, nil)
 
-	// Note: Buckets require manual deletion confirmation via console
-	fmt.Printf("\033[31m[%s] Note: Storage buckets must be deleted manually via GCP Console\033[0m\n", projectID)
-}
+	log.Printf("Starting GCP cleanup script (Dry Run: %v)", *dryRun)
+	log.Printf("Projects to clean: %v", targetProjectIDs)
 
-func deleteVMInstances(ctx context.Context, projectID string) error {
-	log.Printf("[%s] Checking VM instances...", projectID)
+	registry := defaultRegistry(pool, *forceRetention, *forceLocked)
 
-	client, err := compute.NewInstancesRESTClient(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create instances client: %w", err)
-	}
-	defer client.Close()
+	runAllProjects(ctx, registry, resourceFilter, reporter, pool, targetProjectIDs, assumeYes, *parallelProjects, *dryRun)
 
-	// Use aggregated list to get all instances across all zones
-	req := &computepb.AggregatedListInstancesRequest{
-		Project: projectID,
-	}
+	log.Println("\n========== Cleanup Complete ==========")
 
-	// Collect all instances first
-	type instanceInfo struct {
-		name string
-		zone string
+	if err := reporter.WriteJSON(*reportJSONPath); err != nil {
+		log.Printf("Error writing JSON report: %v", err)
 	}
-	var instances []instanceInfo
-
-	it := client.AggregatedList(ctx, req)
-	for {
-		pair, err := it.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("error listing instances: %w", err)
-		}
-
-		for _, instance := range pair.Value.Instances {
-			zone := extractZoneFromURL(instance.GetZone())
-			instances = append(instances, instanceInfo{
-				name: instance.GetName(),
-				zone: zone,
-			})
-			log.Printf("  Found VM Instance: %s (zone: %s, status: %s)",
-				instance.GetName(), zone, instance.GetStatus())
-		}
+	if err := reporter.WriteCSV(*reportCSVPath); err != nil {
+		log.Printf("Error writing CSV report: %v", err)
 	}
 
-	if len(instances) == 0 {
-		log.Printf("[%s] No VM instances found", projectID)
-		return nil
+	if err := reporter.Err(); err != nil {
+		log.Printf("Cleanup finished with errors:\n%v", err)
+		os.Exit(1)
 	}
+}
 
-	if dryRun {
-		log.Printf("[%s] Would delete %d VM instances", projectID, len(instances))
-		return nil
+// runAllProjects runs cleanupProject for every project in projectIDs, up to
+// parallelProjects at once. Concurrency is only across projects: within a
+// single project, cleaners still run strictly in dependency order.
+func runAllProjects(ctx context.Context, registry *Registry, filter Predicate, reporter *Reporter, pool *Pool, targetProjectIDs []string, assumeYes bool, parallelProjects int, dryRun bool) {
+	if parallelProjects <= 0 {
+		parallelProjects = 1
 	}
 
-	// Delete all instances in parallel
-	log.Printf("[%s] Deleting %d VM instances in parallel...", projectID, len(instances))
+	sem := make(chan struct{}, parallelProjects)
 	var wg sync.WaitGroup
-	for _, inst := range instances {
+
+	for _, projectID := range targetProjectIDs {
+		projectID := projectID
 		wg.Add(1)
-		go func(name, zone string) {
+		sem <- struct{}{}
+		go func() {
 			defer wg.Done()
-			deleteReq := &computepb.DeleteInstanceRequest{
-				Project:  projectID,
-				Zone:     zone,
-				Instance: name,
-			}
-			op, err := client.Delete(ctx, deleteReq)
-			if err != nil {
-				log.Printf("  ERROR deleting instance %s: %v", name, err)
-				return
-			}
-			if err := op.Wait(ctx); err != nil {
-				log.Printf("  ERROR waiting for deletion of %s: %v", name, err)
-			} else {
-				log.Printf("  ✓ Deleted VM instance: %s", name)
+			defer func() { <-sem }()
+
+			log.Printf("\n========== Processing Project: %s ==========", projectID)
+			if err := cleanupProject(ctx, registry, filter, reporter, pool, projectID, assumeYes, dryRun); err != nil {
+				log.Printf("Error cleaning up project %s: %v", projectID, err)
 			}
-		}(inst.name, inst.zone)
+		}()
 	}
-	wg.Wait()
-	log.Printf("[%s] All VM deletions complete", projectID)
 
-	return nil
+	wg.Wait()
 }
 
-func deleteDisks(ctx context.Context, projectID string) error {
-	log.Printf("[%s] Checking disks...", projectID)
-
-	client, err := compute.NewDisksRESTClient(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create disks client: %w", err)
-	}
-	defer client.Close()
-
-	// Use aggregated list to get all disks across all zones
-	req := &computepb.AggregatedListDisksRequest{
-		Project: projectID,
-	}
+// defaultRegistry builds the registry of every resource type this tool
+// knows how to clean up. pool is the shared worker pool passed to cleaners
+// that need to batch-delete their own sub-resources (e.g. bucket objects).
+func defaultRegistry(pool *Pool, forceRetention, forceLocked bool) *Registry {
+	r := NewRegistry()
+	r.Register(vmInstanceCleaner{})
+	r.Register(diskCleaner{})
+	r.Register(addressCleaner{})
+	r.Register(serviceAccountCleaner{})
+	r.Register(gkeClusterCleaner{})
+	r.Register(targetPoolCleaner{})
+	r.Register(forwardingRuleCleaner{})
+	r.Register(firewallRuleCleaner{})
+	r.Register(networkCleaner{})
+	r.Register(NewBucketCleaner(pool, forceRetention, forceLocked))
+	return r
+}
 
-	diskCount := 0
-	it := client.AggregatedList(ctx, req)
-	for {
-		pair, err := it.Next()
-		if err == iterator.Done {
-			break
-		}
+// buildFilter combines a --filter-config file (if any) with --include and
+// --exclude flags into the Predicate used to select resources for
+// deletion. With nothing configured it returns MatchNone, so running the
+// tool with no filter flags is a safe no-op rather than a full wipe.
+func buildFilter(configPath string, includeExprs, excludeExprs []string) (Predicate, error) {
+	pred := MatchNone()
+	if configPath != "" {
+		configPred, err := LoadFilterConfig(configPath)
 		if err != nil {
-			return fmt.Errorf("error listing disks: %w", err)
-		}
-
-		for _, disk := range pair.Value.Disks {
-			// Extract zone from the zone URL
-			zone := extractZoneFromURL(disk.GetZone())
-
-			// Skip disks that are attached to instances
-			if len(disk.GetUsers()) > 0 {
-				log.Printf("  Skipping disk %s (attached to instances)", disk.GetName())
-				continue
-			}
-
-			diskCount++
-			log.Printf("  Found Disk: %s (zone: %s, size: %d GB)",
-				disk.GetName(), zone, disk.GetSizeGb())
-
-			if !dryRun {
-				deleteReq := &computepb.DeleteDiskRequest{
-					Project: projectID,
-					Zone:    zone,
-					Disk:    disk.GetName(),
-				}
-				op, err := client.Delete(ctx, deleteReq)
-				if err != nil {
-					log.Printf("  ERROR deleting disk %s: %v", disk.GetName(), err)
-					continue
-				}
-				if err := op.Wait(ctx); err != nil {
-					log.Printf("  ERROR waiting for deletion of %s: %v", disk.GetName(), err)
-				} else {
-					log.Printf("  ✓ Deleted disk: %s", disk.GetName())
-				}
-			}
+			return nil, err
 		}
+		pred = configPred
 	}
 
-	if diskCount == 0 {
-		log.Printf("[%s] No unattached disks found", projectID)
-	} else if dryRun {
-		log.Printf("[%s] Would delete %d disks", projectID, diskCount)
+	if len(includeExprs) == 0 && len(excludeExprs) == 0 {
+		return pred, nil
 	}
 
-	return nil
-}
-
-func releaseStaticIPs(ctx context.Context, projectID string) error {
-	log.Printf("[%s] Checking static IP addresses...", projectID)
-
-	client, err := compute.NewAddressesRESTClient(ctx)
+	cfg := FilterConfig{Include: includeExprs, Exclude: excludeExprs}
+	flagPred, err := cfg.BuildPredicate()
 	if err != nil {
-		return fmt.Errorf("failed to create addresses client: %w", err)
-	}
-	defer client.Close()
-
-	// Use aggregated list to get all addresses across all regions
-	req := &computepb.AggregatedListAddressesRequest{
-		Project: projectID,
-	}
-
-	ipCount := 0
-	it := client.AggregatedList(ctx, req)
-	for {
-		pair, err := it.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("error listing addresses: %w", err)
-		}
-
-		for _, address := range pair.Value.Addresses {
-			// Extract region from the region URL
-			region := extractRegionFromURL(address.GetRegion())
-
-			// Skip addresses that are in use
-			if address.GetStatus() == "IN_USE" {
-				log.Printf("  Skipping address %s (in use)", address.GetName())
-				continue
-			}
-
-			ipCount++
-			log.Printf("  Found Static IP: %s (region: %s, address: %s, status: %s)",
-				address.GetName(), region, address.GetAddress(), address.GetStatus())
-
-			if !dryRun {
-				deleteReq := &computepb.DeleteAddressRequest{
-					Project: projectID,
-					Region:  region,
-					Address: address.GetName(),
-				}
-				op, err := client.Delete(ctx, deleteReq)
-				if err != nil {
-					log.Printf("  ERROR releasing address %s: %v", address.GetName(), err)
-					continue
-				}
-				if err := op.Wait(ctx); err != nil {
-					log.Printf("  ERROR waiting for release of %s: %v", address.GetName(), err)
-				} else {
-					log.Printf("  ✓ Released static IP: %s", address.GetName())
-				}
-			}
-		}
+		return nil, err
 	}
 
-	// Also check global addresses
-	globalReq := &computepb.ListGlobalAddressesRequest{
-		Project: projectID,
+	if configPath == "" {
+		return flagPred, nil
 	}
+	return Or(pred, flagPred), nil
+}
 
-	globalClient, err := compute.NewGlobalAddressesRESTClient(ctx)
+// cleanupProject runs every registered cleaner against projectID in
+// dependency order: a cleaner never runs until everything it Depends() on
+// has finished. Only resources matching filter are deleted. Every
+// resource's outcome is recorded on reporter.
+//
+// Unless dryRun or assumeYes is set, the user is shown a summary of what
+// would be deleted and must type the project ID back before any cleaner
+// actually deletes anything.
+func cleanupProject(ctx context.Context, registry *Registry, filter Predicate, reporter *Reporter, pool *Pool, projectID string, assumeYes, dryRun bool) error {
+	cleaners, err := registry.Ordered()
 	if err != nil {
-		return fmt.Errorf("failed to create global addresses client: %w", err)
+		return fmt.Errorf("failed to order resource cleaners: %w", err)
 	}
-	defer globalClient.Close()
 
-	globalIt := globalClient.List(ctx, globalReq)
-	for {
-		globalAddr, err := globalIt.Next()
-		if err == iterator.Done {
-			break
-		}
+	toDelete := make(map[string][]Resource, len(cleaners))
+	var summary []projectSummary
+
+	for _, cleaner := range cleaners {
+		resources, err := listFiltered(ctx, cleaner, filter, reporter, projectID)
 		if err != nil {
-			log.Printf("Error listing global addresses: %v", err)
-			break
+			log.Printf("Error listing %s in %s: %v", cleaner.Name(), projectID, err)
+			continue
 		}
-
-		if globalAddr.GetStatus() == "IN_USE" {
-			log.Printf("  Skipping global address %s (in use)", globalAddr.GetName())
+		if len(resources) == 0 {
 			continue
 		}
+		toDelete[cleaner.Name()] = resources
+		summary = append(summary, projectSummary{resourceType: cleaner.Name(), count: len(resources)})
+	}
 
-		ipCount++
-		log.Printf("  Found Global Static IP: %s (address: %s, status: %s)",
-			globalAddr.GetName(), globalAddr.GetAddress(), globalAddr.GetStatus())
-
-		if !dryRun {
-			deleteReq := &computepb.DeleteGlobalAddressRequest{
-				Project: projectID,
-				Address: globalAddr.GetName(),
+	if len(summary) == 0 {
+		log.Printf("[%s] Nothing to delete", projectID)
+	} else if dryRun {
+		printProjectSummary(projectID, summary)
+		for _, cleaner := range cleaners {
+			for _, resource := range toDelete[cleaner.Name()] {
+				reporter.Record(ReportEntry{
+					ProjectID:    projectID,
+					ResourceType: cleaner.Name(),
+					Name:         resource.Name,
+					Location:     resource.Location,
+					Outcome:      OutcomeDryRun,
+				})
 			}
-			op, err := globalClient.Delete(ctx, deleteReq)
-			if err != nil {
-				log.Printf("  ERROR releasing global address %s: %v", globalAddr.GetName(), err)
+		}
+		log.Printf("[%s] Dry run: nothing deleted", projectID)
+	} else if !assumeYes && !confirmProjectInteractive(projectID, summary) {
+		log.Printf("[%s] Confirmation failed or declined; skipping deletion", projectID)
+	} else {
+		if assumeYes {
+			printProjectSummary(projectID, summary)
+		}
+		for _, cleaner := range cleaners {
+			resources := toDelete[cleaner.Name()]
+			if len(resources) == 0 {
 				continue
 			}
-			if err := op.Wait(ctx); err != nil {
-				log.Printf("  ERROR waiting for release of %s: %v", globalAddr.GetName(), err)
-			} else {
-				log.Printf("  ✓ Released global static IP: %s", globalAddr.GetName())
-			}
+			deleteResources(ctx, cleaner, resources, reporter, pool, projectID)
 		}
 	}
 
-	if ipCount == 0 {
-		log.Printf("[%s] No unused static IPs found", projectID)
-	} else if dryRun {
-		log.Printf("[%s] Would release %d static IPs", projectID, ipCount)
-	}
-
 	return nil
 }
 
-func deleteBuckets(ctx context.Context, projectID string) error {
-	log.Printf("[%s] Checking storage buckets...", projectID)
+// listFiltered lists every resource of one type and keeps only the ones
+// filter selects, recording the rest as skipped on reporter.
+func listFiltered(ctx context.Context, cleaner ResourceCleaner, filter Predicate, reporter *Reporter, projectID string) ([]Resource, error) {
+	log.Printf("[%s] Checking %s...", projectID, cleaner.Name())
 
-	client, err := storage.NewClient(ctx)
+	found, err := cleaner.List(ctx, projectID)
 	if err != nil {
-		return fmt.Errorf("failed to create storage client: %w", err)
-	}
-	defer client.Close()
-
-	it := client.Buckets(ctx, projectID)
-	bucketCount := 0
-
-	for {
-		attrs, err := it.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return fmt.Errorf("error listing buckets: %w", err)
+		return nil, fmt.Errorf("error listing %s: %w", cleaner.Name(), err)
+	}
+
+	var resources []Resource
+	for _, resource := range found {
+		if !filter.Match(resource) {
+			log.Printf("  Skipping %s: %s (location: %s, does not match filter)", cleaner.Name(), resource.Name, resource.Location)
+			reporter.Record(ReportEntry{
+				ProjectID:    projectID,
+				ResourceType: cleaner.Name(),
+				Name:         resource.Name,
+				Location:     resource.Location,
+				Outcome:      OutcomeSkipped,
+			})
+			continue
 		}
+		log.Printf("  Found %s: %s (location: %s)", cleaner.Name(), resource.Name, resource.Location)
+		resources = append(resources, resource)
+	}
 
-		bucketCount++
-		log.Printf("  Found Bucket: %s (location: %s, storage class: %s)",
-			attrs.Name, attrs.Location, attrs.StorageClass)
-
-		if !dryRun {
-			bucket := client.Bucket(attrs.Name)
-
-			// Delete all objects in the bucket first
-			log.Printf("  Deleting objects in bucket %s...", attrs.Name)
-			objIt := bucket.Objects(ctx, nil)
-			objCount := 0
-			for {
-				objAttrs, err := objIt.Next()
-				if err == iterator.Done {
-					break
-				}
-				if err != nil {
-					log.Printf("  ERROR listing objects in bucket %s: %v", attrs.Name, err)
-					break
-				}
-
-				if err := bucket.Object(objAttrs.Name).Delete(ctx); err != nil {
-					log.Printf("  ERROR deleting object %s: %v", objAttrs.Name, err)
-				} else {
-					objCount++
-				}
-			}
+	return resources, nil
+}
 
-			if objCount > 0 {
-				log.Printf("  Deleted %d objects from bucket %s", objCount, attrs.Name)
+// deleteResources submits each resource's deletion to pool, rendering a
+// progress bar as they complete, and records each outcome on reporter.
+func deleteResources(ctx context.Context, cleaner ResourceCleaner, resources []Resource, reporter *Reporter, pool *Pool, projectID string) {
+	log.Printf("[%s] Deleting %d %s...", projectID, len(resources), cleaner.Name())
+
+	family := apiFamilyForCleaner(cleaner.Name())
+	bar := NewProgressBar(fmt.Sprintf("[%s] Deleting %s", projectID, cleaner.Name()), len(resources))
+
+	batch := pool.NewBatch()
+	for _, resource := range resources {
+		resource := resource
+		batch.Go(ctx, family, func() error {
+			start := time.Now()
+			delErr := RetryWithBackoff(ctx, func() error {
+				return cleaner.Delete(ctx, projectID, resource)
+			})
+			entry := ReportEntry{
+				ProjectID:    projectID,
+				ResourceType: cleaner.Name(),
+				Name:         resource.Name,
+				Location:     resource.Location,
+				Duration:     time.Since(start),
 			}
-
-			// Now delete the bucket
-			if err := bucket.Delete(ctx); err != nil {
-				log.Printf("  ERROR deleting bucket %s: %v", attrs.Name, err)
+			if delErr != nil {
+				log.Printf("  ERROR deleting %s %s: %v", cleaner.Name(), resource.Name, delErr)
+				entry.Outcome = OutcomeError
+				entry.Error = delErr.Error()
 			} else {
-				log.Printf("  ✓ Deleted bucket: %s", attrs.Name)
+				log.Printf("  ✓ Deleted %s: %s", cleaner.Name(), resource.Name)
+				entry.Outcome = OutcomeDeleted
 			}
-		}
+			reporter.Record(entry)
+			bar.Increment()
+			return delErr
+		})
 	}
-
-	if bucketCount == 0 {
-		log.Printf("[%s] No buckets found", projectID)
-	} else if dryRun {
-		log.Printf("[%s] Would delete %d buckets", projectID, bucketCount)
+	if err := batch.Wait(); err != nil {
+		log.Printf("[%s] Worker pool error while deleting %s: %v", projectID, cleaner.Name(), err)
 	}
-
-	return nil
+	bar.Finish()
+	log.Printf("[%s] All %s deletions complete", projectID, cleaner.Name())
 }
 
 // Helper function to extract zone name from zone URL
@@ -441,59 +329,3 @@ func extractRegionFromURL(regionURL string) string {
 	}
 	return regionURL
 }
-
-func deleteServiceAccounts(ctx context.Context, projectID string) error {
-	log.Printf("[%s] Checking service accounts...", projectID)
-
-	iamService, err := iam.NewService(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create IAM service: %w", err)
-	}
-
-	// List all service accounts
-	resp, err := iamService.Projects.ServiceAccounts.List("projects/" + projectID).Do()
-	if err != nil {
-		return fmt.Errorf("failed to list service accounts: %w", err)
-	}
-
-	// Filter service accounts that start with "vsa-sa-gcnv"
-	var targetAccounts []*iam.ServiceAccount
-	for _, sa := range resp.Accounts {
-		// Extract the email local part (before @)
-		emailParts := strings.Split(sa.Email, "@")
-		if len(emailParts) > 0 && strings.HasPrefix(emailParts[0], "vsa-sa-gcnv") {
-			targetAccounts = append(targetAccounts, sa)
-			log.Printf("  Found Service Account: %s (%s)", sa.Email, sa.DisplayName)
-		}
-	}
-
-	if len(targetAccounts) == 0 {
-		log.Printf("[%s] No service accounts found with prefix 'vsa-sa-gcnv'", projectID)
-		return nil
-	}
-
-	if dryRun {
-		log.Printf("[%s] Would delete %d service accounts", projectID, len(targetAccounts))
-		return nil
-	}
-
-	// Delete service accounts in parallel
-	log.Printf("[%s] Deleting %d service accounts in parallel...", projectID, len(targetAccounts))
-	var wg sync.WaitGroup
-	for _, sa := range targetAccounts {
-		wg.Add(1)
-		go func(account *iam.ServiceAccount) {
-			defer wg.Done()
-			_, err := iamService.Projects.ServiceAccounts.Delete(account.Name).Do()
-			if err != nil {
-				log.Printf("  ERROR deleting service account %s: %v", account.Email, err)
-			} else {
-				log.Printf("  ✓ Deleted service account: %s", account.Email)
-			}
-		}(sa)
-	}
-	wg.Wait()
-	log.Printf("[%s] All service account deletions complete", projectID)
-
-	return nil
-}
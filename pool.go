@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// APIFamily groups GCP API calls that share a single quota, so the pool can
+// rate-limit them independently of how many cleaners happen to be calling
+// that API concurrently.
+type APIFamily string
+
+const (
+	FamilyCompute APIFamily = "compute"
+	FamilyIAM     APIFamily = "iam"
+	FamilyStorage APIFamily = "storage"
+)
+
+// defaultQPS are conservative defaults for each API family's token bucket,
+// well under GCE/IAM/GCS per-project quotas.
+var defaultQPS = map[APIFamily]float64{
+	FamilyCompute: 20,
+	FamilyIAM:     10,
+	FamilyStorage: 20,
+}
+
+// Pool bounds how many delete operations run at once and rate-limits them
+// per APIFamily, so large projects don't trip per-project QPS quotas. It
+// holds no per-call state itself: every caller gets its own Batch via
+// NewBatch, so concurrent callers (e.g. multiple projects under
+// --parallel-projects, or a cleaner batch-deleting a bucket's objects from
+// inside a task already running on this same Pool) never wait on or absorb
+// errors from each other, while still sharing one rate limiter per
+// APIFamily process-wide.
+type Pool struct {
+	sem      chan struct{}
+	limiters map[APIFamily]*rate.Limiter
+}
+
+// NewPool returns a Pool that runs at most maxParallel tasks at once,
+// rate-limiting each APIFamily according to qps (falling back to
+// defaultQPS for any family not present in qps).
+func NewPool(maxParallel int, qps map[APIFamily]float64) *Pool {
+	if maxParallel <= 0 {
+		maxParallel = 10
+	}
+
+	limiters := make(map[APIFamily]*rate.Limiter, len(defaultQPS))
+	for family, rps := range defaultQPS {
+		if override, ok := qps[family]; ok {
+			rps = override
+		}
+		limiters[family] = rate.NewLimiter(rate.Limit(rps), 1)
+	}
+
+	return &Pool{
+		sem:      make(chan struct{}, maxParallel),
+		limiters: limiters,
+	}
+}
+
+// NewBatch returns a Batch that submits work to p's shared worker slots and
+// rate limiters, but tracks its own completion and errors independently of
+// any other Batch drawing from the same Pool.
+func (p *Pool) NewBatch() *Batch {
+	return &Batch{pool: p}
+}
+
+// Batch tracks one logical unit of work (e.g. deleting every resource of one
+// type in one project) submitted to a shared Pool. Each call site should
+// create its own Batch so its Wait only ever blocks on, and its errors only
+// ever reflect, the tasks it submitted.
+type Batch struct {
+	pool *Pool
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// Go submits task to run under family's rate limit as soon as a worker
+// slot is free. Errors are collected, not returned, until Wait is called.
+func (b *Batch) Go(ctx context.Context, family APIFamily, task func() error) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+
+		select {
+		case b.pool.sem <- struct{}{}:
+		case <-ctx.Done():
+			b.addErr(ctx.Err())
+			return
+		}
+		defer func() { <-b.pool.sem }()
+
+		if limiter, ok := b.pool.limiters[family]; ok {
+			if err := limiter.Wait(ctx); err != nil {
+				b.addErr(err)
+				return
+			}
+		}
+
+		if err := task(); err != nil {
+			b.addErr(err)
+		}
+	}()
+}
+
+func (b *Batch) addErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.errs = append(b.errs, err)
+}
+
+// Wait blocks until every task submitted to b has finished and returns
+// their errors joined via errors.Join, or nil if none failed.
+func (b *Batch) Wait() error {
+	b.wg.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return errors.Join(b.errs...)
+}
+
+// retryableError reports whether err looks like a transient GCP API error
+// (HTTP 429 or 503) worth retrying.
+func retryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr interface{ Code() int32 }
+	if errors.As(err, &apiErr) {
+		code := apiErr.Code()
+		return code == 429 || code == 503
+	}
+	// googleapi.Error exposes Code as a plain int rather than a method; cloud
+	// clients typically wrap one of the two, so check both shapes.
+	var httpErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &httpErr) {
+		code := httpErr.HTTPStatusCode()
+		return code == 429 || code == 503
+	}
+	return false
+}
+
+// RetryWithBackoff retries fn while it returns a retryable error, waiting
+// an exponentially increasing, jittered delay between attempts. It gives up
+// once ctx is done or after maxAttempts tries.
+func RetryWithBackoff(ctx context.Context, fn func() error) error {
+	const (
+		maxAttempts = 5
+		baseDelay   = 500 * time.Millisecond
+		maxDelay    = 30 * time.Second
+	)
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		err = fn()
+		if err == nil || !retryableError(err) {
+			return err
+		}
+
+		delay := baseDelay * time.Duration(1<<attempt)
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	return err
+}
+
+// apiFamilyForCleaner maps a ResourceCleaner's Name() to the APIFamily its
+// calls should be rate-limited under.
+func apiFamilyForCleaner(name string) APIFamily {
+	switch name {
+	case "service-accounts":
+		return FamilyIAM
+	case "buckets":
+		return FamilyStorage
+	default:
+		return FamilyCompute
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// ProgressBar renders a single-line "Deleting VMs [====>    ] 12/47" bar
+// to stderr, updated as a worker pool's tasks complete. It is a no-op when
+// stderr isn't a terminal, so CI logs and redirected output stay clean.
+type ProgressBar struct {
+	mu      sync.Mutex
+	label   string
+	total   int
+	done    int
+	enabled bool
+}
+
+const progressBarWidth = 20
+
+// NewProgressBar returns a bar for total items labeled label. Rendering is
+// automatically disabled when stderr is not an interactive terminal.
+func NewProgressBar(label string, total int) *ProgressBar {
+	return &ProgressBar{
+		label:   label,
+		total:   total,
+		enabled: term.IsTerminal(int(os.Stderr.Fd())),
+	}
+}
+
+// Increment advances the bar by one completed item and redraws it.
+func (b *ProgressBar) Increment() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.done++
+	b.render()
+}
+
+// Finish redraws the bar at completion and moves to a new line.
+func (b *ProgressBar) Finish() {
+	if !b.enabled {
+		return
+	}
+	fmt.Fprintln(os.Stderr)
+}
+
+func (b *ProgressBar) render() {
+	if !b.enabled {
+		return
+	}
+
+	filled := 0
+	if b.total > 0 {
+		filled = progressBarWidth * b.done / b.total
+	}
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	fmt.Fprintf(os.Stderr, "\r%s [%s] %d/%d", b.label, bar, b.done, b.total)
+}
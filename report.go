@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Outcome is the terminal state of a single resource operation.
+type Outcome string
+
+const (
+	OutcomeDeleted Outcome = "deleted"
+	OutcomeDryRun  Outcome = "dry-run"
+	OutcomeSkipped Outcome = "skipped"
+	OutcomeError   Outcome = "error"
+)
+
+// ReportEntry records what happened to one resource during a run.
+type ReportEntry struct {
+	ProjectID    string        `json:"project_id"`
+	ResourceType string        `json:"resource_type"`
+	Name         string        `json:"name"`
+	Location     string        `json:"location"`
+	Outcome      Outcome       `json:"outcome"`
+	Duration     time.Duration `json:"duration_ns"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// Reporter accumulates ReportEntry values from every cleaner, possibly
+// called from multiple goroutines, and renders them as JSON/CSV once the
+// run is complete.
+type Reporter struct {
+	mu      sync.Mutex
+	entries []ReportEntry
+	errs    []error
+}
+
+// NewReporter returns an empty Reporter.
+func NewReporter() *Reporter {
+	return &Reporter{}
+}
+
+// Record appends entry to the report. It is safe to call concurrently.
+func (r *Reporter) Record(entry ReportEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+	if entry.Outcome == OutcomeError {
+		r.errs = append(r.errs, fmt.Errorf("%s/%s %s: %s", entry.ProjectID, entry.ResourceType, entry.Name, entry.Error))
+	}
+}
+
+// Err returns every recorded error joined via errors.Join, or nil if the
+// run had none.
+func (r *Reporter) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return errors.Join(r.errs...)
+}
+
+// WriteJSON writes every recorded entry to path as a JSON array.
+func (r *Reporter) WriteJSON(path string) error {
+	r.mu.Lock()
+	entries := append([]ReportEntry(nil), r.entries...)
+	r.mu.Unlock()
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+	return nil
+}
+
+// WriteCSV writes every recorded entry to path as CSV, one row per
+// resource.
+func (r *Reporter) WriteCSV(path string) error {
+	r.mu.Lock()
+	entries := append([]ReportEntry(nil), r.entries...)
+	r.mu.Unlock()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	header := []string{"project_id", "resource_type", "name", "location", "outcome", "duration_ns", "error"}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write report header: %w", err)
+	}
+
+	for _, e := range entries {
+		row := []string{
+			e.ProjectID,
+			e.ResourceType,
+			e.Name,
+			e.Location,
+			string(e.Outcome),
+			strconv.FormatInt(int64(e.Duration), 10),
+			e.Error,
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write report row for %s: %w", e.Name, err)
+		}
+	}
+
+	return w.Error()
+}
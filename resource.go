@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Resource is the common shape every cleaner reports a discovered GCP
+// resource as, regardless of which API it came from.
+type Resource struct {
+	Name       string
+	Location   string // zone, region, or "global"
+	Labels     map[string]string
+	CreateTime string
+	Status     string
+	Users      []string // e.g. instances a disk is attached to, or a target pool's instances
+}
+
+// ResourceCleaner is implemented by each GCP resource type this tool knows
+// how to clean up. Implementations are registered with a Registry so that
+// cleanupProject never needs to know about concrete resource types.
+type ResourceCleaner interface {
+	// Name identifies the resource type, e.g. "vm-instances" or "gke-clusters".
+	Name() string
+
+	// List returns every resource of this type found in projectID.
+	List(ctx context.Context, projectID string) ([]Resource, error)
+
+	// Delete removes a single resource previously returned by List.
+	Delete(ctx context.Context, projectID string, resource Resource) error
+
+	// Dependencies names the cleaners that must run to completion before
+	// this one starts, e.g. target pools depend on VM instances.
+	Dependencies() []string
+}
+
+// Registry holds the set of known cleaners and orders them for execution.
+type Registry struct {
+	cleaners map[string]ResourceCleaner
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{cleaners: make(map[string]ResourceCleaner)}
+}
+
+// Register adds a cleaner to the registry. It panics on a duplicate Name,
+// which indicates a programming error rather than something callers should
+// need to handle at runtime.
+func (r *Registry) Register(c ResourceCleaner) {
+	if _, exists := r.cleaners[c.Name()]; exists {
+		panic(fmt.Sprintf("resource cleaner %q registered twice", c.Name()))
+	}
+	r.cleaners[c.Name()] = c
+}
+
+// Ordered returns the registered cleaners topologically sorted so that every
+// cleaner appears after the cleaners it Depends() on. It returns an error if
+// a dependency name is unknown or the dependency graph has a cycle.
+func (r *Registry) Ordered() ([]ResourceCleaner, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(r.cleaners))
+	var order []ResourceCleaner
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle detected at resource cleaner %q", name)
+		}
+
+		c, ok := r.cleaners[name]
+		if !ok {
+			return fmt.Errorf("unknown resource cleaner %q", name)
+		}
+
+		state[name] = visiting
+		for _, dep := range c.Dependencies() {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, c)
+		return nil
+	}
+
+	names := make([]string, 0, len(r.cleaners))
+	for name := range r.cleaners {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
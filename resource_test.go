@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+// stubCleaner is a minimal ResourceCleaner for exercising Registry.Ordered
+// without pulling in any real GCP client.
+type stubCleaner struct {
+	name string
+	deps []string
+}
+
+func (c stubCleaner) Name() string                                     { return c.name }
+func (c stubCleaner) Dependencies() []string                           { return c.deps }
+func (c stubCleaner) List(context.Context, string) ([]Resource, error) { return nil, nil }
+func (c stubCleaner) Delete(context.Context, string, Resource) error   { return nil }
+
+func indexOf(order []ResourceCleaner, name string) int {
+	for i, c := range order {
+		if c.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestRegistryOrderedRespectsDependencies(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubCleaner{name: "vm-instances"})
+	r.Register(stubCleaner{name: "disks", deps: []string{"vm-instances"}})
+	r.Register(stubCleaner{name: "target-pools", deps: []string{"forwarding-rules"}})
+	r.Register(stubCleaner{name: "forwarding-rules"})
+	r.Register(stubCleaner{name: "vpc-networks", deps: []string{"forwarding-rules", "target-pools", "vm-instances"}})
+
+	order, err := r.Ordered()
+	if err != nil {
+		t.Fatalf("Ordered() returned error: %v", err)
+	}
+	if len(order) != 5 {
+		t.Fatalf("Ordered() returned %d cleaners, want 5", len(order))
+	}
+
+	for _, c := range order {
+		for _, dep := range c.Dependencies() {
+			if indexOf(order, dep) >= indexOf(order, c.Name()) {
+				t.Errorf("cleaner %q ran before its dependency %q", c.Name(), dep)
+			}
+		}
+	}
+}
+
+func TestRegistryOrderedIsDeterministic(t *testing.T) {
+	build := func() *Registry {
+		r := NewRegistry()
+		r.Register(stubCleaner{name: "b", deps: []string{"a"}})
+		r.Register(stubCleaner{name: "a"})
+		r.Register(stubCleaner{name: "c", deps: []string{"a"}})
+		return r
+	}
+
+	first, err := build().Ordered()
+	if err != nil {
+		t.Fatalf("Ordered() returned error: %v", err)
+	}
+	second, err := build().Ordered()
+	if err != nil {
+		t.Fatalf("Ordered() returned error: %v", err)
+	}
+
+	if len(first) != len(second) {
+		t.Fatalf("got differing lengths %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i].Name() != second[i].Name() {
+			t.Errorf("order differs at index %d: %q vs %q", i, first[i].Name(), second[i].Name())
+		}
+	}
+}
+
+func TestRegistryOrderedDetectsCycle(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubCleaner{name: "a", deps: []string{"b"}})
+	r.Register(stubCleaner{name: "b", deps: []string{"a"}})
+
+	if _, err := r.Ordered(); err == nil {
+		t.Error("Ordered() returned nil error for a cyclic dependency graph, want an error")
+	}
+}
+
+func TestRegistryOrderedDetectsUnknownDependency(t *testing.T) {
+	r := NewRegistry()
+	r.Register(stubCleaner{name: "a", deps: []string{"does-not-exist"}})
+
+	if _, err := r.Ordered(); err == nil {
+		t.Error("Ordered() returned nil error for an unknown dependency, want an error")
+	}
+}
+
+func TestRegistryRegisterPanicsOnDuplicateName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Register did not panic on a duplicate cleaner name")
+		}
+	}()
+
+	r := NewRegistry()
+	r.Register(stubCleaner{name: "vm-instances"})
+	r.Register(stubCleaner{name: "vm-instances"})
+}